@@ -0,0 +1,21 @@
+package fileitem
+
+import "context"
+
+// Backend is the storage backend a FileItem persists its set to. Concrete
+// implementations live in subpackages (osfs, memfs, s3) so that FileItem
+// itself stays storage-agnostic and the item-set logic can be exercised
+// without touching disk.
+type Backend interface {
+	// Read returns the full contents currently held by the backend. A
+	// backend with nothing stored yet should return an empty, non-nil
+	// slice rather than an error.
+	Read(ctx context.Context) ([]byte, error)
+
+	// Append adds entry to the end of the backend's contents, on its own
+	// line, without otherwise disturbing what's already stored.
+	Append(ctx context.Context, entry string) error
+
+	// Rewrite replaces the backend's entire contents with data.
+	Rewrite(ctx context.Context, data []byte) error
+}
@@ -0,0 +1,111 @@
+package fileitem
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Tx stages edits to a FileItem's item set for a Batch. Nothing it does is
+// visible to the backend (or to other callers of FileItem) until the batch
+// it belongs to commits. Staged entries are keyed by their lowercase form
+// but keep their original-case spelling, so a Batch commit preserves case
+// the same way FileItem.Add does.
+type Tx struct {
+	items map[string]string
+}
+
+// Add stages item for addition, as FileItem.Add would.
+func (tx *Tx) Add(item string) error {
+	item = strings.TrimSpace(item)
+	if item == "" {
+		return errors.New("cannot add empty entry")
+	}
+
+	lower := strings.ToLower(item)
+	if _, ok := tx.items[lower]; ok {
+		// Already contains this item.
+		return errors.New("already exists")
+	}
+
+	tx.items[lower] = item
+	return nil
+}
+
+// Remove stages item for removal, as FileItem.Remove would.
+func (tx *Tx) Remove(item string) error {
+	item = strings.TrimSpace(item)
+	if item == "" {
+		return errors.New("cannot remove empty entry")
+	}
+
+	lower := strings.ToLower(item)
+	if _, ok := tx.items[lower]; !ok {
+		// Unknown item (not exists in items set)
+		return errors.New("entry not found")
+	}
+
+	delete(tx.items, lower)
+	return nil
+}
+
+// Contains reports whether item is staged in tx, as FileItem.Contains would.
+func (tx *Tx) Contains(item string) bool {
+	item = strings.TrimSpace(item)
+	if item == "" {
+		return false
+	}
+	_, ok := tx.items[strings.ToLower(item)]
+	return ok
+}
+
+// Batch runs fn against a staged copy of the FileItem's item set. If fn
+// returns nil, the staged set is committed with a single rewrite of the
+// backend; otherwise the staged edits are discarded and fi is left
+// untouched. fi's in-memory set and backend are only updated once the
+// rewrite succeeds, so a failing backend (disk full, permission error, ...)
+// leaves fi exactly as it was rather than disagreeing with what's actually
+// persisted. This avoids a per-entry backend round trip when editing many
+// entries at once, and gives callers all-or-nothing semantics. On commit,
+// an OpAdd/OpRemove Event is published for every entry that was actually
+// added or removed, the same as Add/Remove would publish individually.
+func (fi *FileItem) Batch(fn func(tx *Tx) error) error {
+	ctx := context.Background()
+	if err := fi.itemsMu.Lock(ctx); err != nil {
+		return err
+	}
+	defer fi.itemsMu.Unlock()
+
+	staged := make(map[string]string, len(fi.items))
+	for lower, original := range fi.items {
+		staged[lower] = original
+	}
+
+	if err := fn(&Tx{items: staged}); err != nil {
+		return err
+	}
+
+	lines := make([]string, 0, len(staged))
+	for _, original := range staged {
+		lines = append(lines, original)
+	}
+
+	if err := fi.backend.Rewrite(ctx, []byte(strings.Join(lines, "\n"))); err != nil {
+		return err
+	}
+
+	before := fi.items
+	fi.items = staged
+
+	for lower, item := range staged {
+		if _, ok := before[lower]; !ok {
+			fi.publish(Event{Op: OpAdd, Item: item})
+		}
+	}
+	for lower, item := range before {
+		if _, ok := staged[lower]; !ok {
+			fi.publish(Event{Op: OpRemove, Item: item})
+		}
+	}
+	return nil
+}
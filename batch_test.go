@@ -0,0 +1,116 @@
+package fileitem_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k4ties/fileitem"
+	"github.com/k4ties/fileitem/memfs"
+)
+
+// failingRewriteBackend wraps a memfs.Backend but always fails Rewrite, to
+// exercise what happens when a Batch's backend commit fails.
+type failingRewriteBackend struct {
+	*memfs.Backend
+}
+
+func (b *failingRewriteBackend) Rewrite(context.Context, []byte) error {
+	return errors.New("backend unavailable")
+}
+
+func TestBatchCommitsAllStagedEdits(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(memfs.New())
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+	if err := fi.Add("existing"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	err = fi.Batch(func(tx *fileitem.Tx) error {
+		if err := tx.Add("new-one"); err != nil {
+			return err
+		}
+		return tx.Remove("existing")
+	})
+	if err != nil {
+		t.Fatalf("Batch: %v", err)
+	}
+
+	if fi.Contains("existing") {
+		t.Error("existing should have been removed by the batch")
+	}
+	if !fi.Contains("new-one") {
+		t.Error("new-one should have been added by the batch")
+	}
+}
+
+func TestBatchRollsBackOnFnError(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(memfs.New())
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+	if err := fi.Add("kept"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	wantErr := errors.New("stop")
+	err = fi.Batch(func(tx *fileitem.Tx) error {
+		if err := tx.Remove("kept"); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Batch error = %v, want %v", err, wantErr)
+	}
+	if !fi.Contains("kept") {
+		t.Error("kept should still be present after a failed batch")
+	}
+}
+
+func TestBatchLeavesStateUntouchedOnBackendError(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(&failingRewriteBackend{Backend: memfs.New()})
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+	if err := fi.Add("kept"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	err = fi.Batch(func(tx *fileitem.Tx) error {
+		return tx.Add("should-not-persist")
+	})
+	if err == nil {
+		t.Fatal("expected Batch to surface the backend's Rewrite error")
+	}
+	if fi.Contains("should-not-persist") {
+		t.Error("a failed commit must not leave the staged set applied in memory")
+	}
+	if !fi.Contains("kept") {
+		t.Error("a failed commit must not lose what was already there")
+	}
+}
+
+func TestBatchPreservesItemCase(t *testing.T) {
+	backend := memfs.New()
+	fi, err := fileitem.NewWithBackend(backend)
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+
+	if err := fi.Batch(func(tx *fileitem.Tx) error {
+		return tx.Add("MixedCase")
+	}); err != nil {
+		t.Fatalf("Batch: %v", err)
+	}
+
+	data, err := backend.Read(context.Background())
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got, want := string(data), "MixedCase"; got != want {
+		t.Fatalf("backend contents = %q, want %q (original case lost)", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+package fileitem_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k4ties/fileitem"
+	"github.com/k4ties/fileitem/memfs"
+)
+
+// heldLock starts a Batch that blocks holding fi's lock until release is
+// closed, so context-cancellation tests can force a deterministic wait on
+// lock acquisition rather than racing against an uncontended one.
+func heldLock(t *testing.T, fi *fileitem.FileItem) (release func()) {
+	t.Helper()
+	locked := make(chan struct{})
+	releaseCh := make(chan struct{})
+	go func() {
+		_ = fi.Batch(func(tx *fileitem.Tx) error {
+			close(locked)
+			<-releaseCh
+			return nil
+		})
+	}()
+	<-locked
+	return func() { close(releaseCh) }
+}
+
+func TestAddContextRespectsCancellation(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(memfs.New())
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+	release := heldLock(t, fi)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = fi.AddContext(ctx, "blocked")
+	release()
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("AddContext error = %v, want context.Canceled", err)
+	}
+	if fi.Contains("blocked") {
+		t.Error("AddContext must not add the item once ctx is cancelled")
+	}
+}
+
+func TestRemoveContextRespectsCancellation(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(memfs.New())
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+	if err := fi.Add("present"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	release := heldLock(t, fi)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = fi.RemoveContext(ctx, "present")
+	release()
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RemoveContext error = %v, want context.Canceled", err)
+	}
+	if !fi.Contains("present") {
+		t.Error("RemoveContext must not remove the item once ctx is cancelled")
+	}
+}
+
+func TestContainsContextRespectsCancellation(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(memfs.New())
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+	release := heldLock(t, fi)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = fi.ContainsContext(ctx, "anything")
+	release()
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ContainsContext error = %v, want context.Canceled", err)
+	}
+}
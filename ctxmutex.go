@@ -0,0 +1,31 @@
+package fileitem
+
+import "context"
+
+// ctxMutex is a mutual-exclusion lock whose Lock can be cancelled via a
+// context, implemented as a buffered channel rather than sync.Mutex so
+// acquisition can race against ctx.Done.
+type ctxMutex chan struct{}
+
+// newCtxMutex returns an unlocked ctxMutex.
+func newCtxMutex() ctxMutex {
+	m := make(ctxMutex, 1)
+	m <- struct{}{}
+	return m
+}
+
+// Lock blocks until the lock is acquired or ctx is done, whichever comes
+// first.
+func (m ctxMutex) Lock(ctx context.Context) error {
+	select {
+	case <-m:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Unlock releases the lock.
+func (m ctxMutex) Unlock() {
+	m <- struct{}{}
+}
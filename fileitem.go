@@ -1,38 +1,57 @@
 package fileitem
 
 import (
+	"context"
 	"errors"
-	"github.com/k4ties/gq"
+	"github.com/k4ties/fileitem/osfs"
 	"iter"
-	"os"
 	"strings"
 	"sync"
 )
 
-// FileItem is a structure, that allows you to store items either in the text
-// file and this structure memory.
+// FileItem is a structure, that allows you to store items either in a
+// backend and this structure's memory. items is keyed by an entry's
+// lowercase form but keeps its original-case spelling as the value, so
+// the original casing always round-trips back to the backend.
 type FileItem struct {
-	path    string
-	items   gq.Set[string]
-	itemsMu sync.Mutex
+	backend Backend
+	items   map[string]string
+	itemsMu ctxMutex
+
+	subsMu sync.Mutex
+	subs   map[chan Event]struct{}
 }
 
-// New creates new FileItem instance.
+// New creates a new FileItem instance backed by a text file at path.
 func New(path string) (*FileItem, error) {
+	return NewWithBackend(osfs.New(path))
+}
+
+// NewWithBackend creates a new FileItem instance backed by b.
+func NewWithBackend(b Backend) (*FileItem, error) {
 	fi := &FileItem{
-		path:  path,
-		items: make(gq.Set[string]),
+		backend: b,
+		items:   make(map[string]string),
+		itemsMu: newCtxMutex(),
 	}
-	// Initialising the FileItem (load from path)
-	if err := fi.load(); err != nil {
+	// Initialising the FileItem (load from backend)
+	if err := fi.loadContext(context.Background()); err != nil {
 		return nil, err
 	}
 	return fi, nil
 }
 
-// Add tries to add item to FileItem memory and sync with file.
+// Add tries to add item to FileItem memory and sync with the backend.
 func (fi *FileItem) Add(item string) error {
-	fi.itemsMu.Lock()
+	return fi.AddContext(context.Background(), item)
+}
+
+// AddContext is Add, honoring ctx for both lock acquisition and the
+// backend call it makes.
+func (fi *FileItem) AddContext(ctx context.Context, item string) error {
+	if err := fi.itemsMu.Lock(ctx); err != nil {
+		return err
+	}
 	defer fi.itemsMu.Unlock()
 
 	item = strings.TrimSpace(item)
@@ -46,19 +65,28 @@ func (fi *FileItem) Add(item string) error {
 	}
 
 	// Asserting to the memory first
-	fi.items.Add(strings.ToLower(item))
+	fi.items[strings.ToLower(item)] = item
 
-	// Then, trying to assert to file
-	if err := fi.appendToFile(item); err != nil {
+	// Then, trying to assert to the backend
+	if err := fi.backend.Append(ctx, item); err != nil {
 		return err
 	}
 
+	fi.publish(Event{Op: OpAdd, Item: item})
 	return nil
 }
 
 // Remove tries to remove the item from FileItem memory.
 func (fi *FileItem) Remove(item string) error {
-	fi.itemsMu.Lock()
+	return fi.RemoveContext(context.Background(), item)
+}
+
+// RemoveContext is Remove, honoring ctx for both lock acquisition and the
+// backend call it makes.
+func (fi *FileItem) RemoveContext(ctx context.Context, item string) error {
+	if err := fi.itemsMu.Lock(ctx); err != nil {
+		return err
+	}
 	defer fi.itemsMu.Unlock()
 
 	item = strings.TrimSpace(item)
@@ -66,11 +94,16 @@ func (fi *FileItem) Remove(item string) error {
 		return errors.New("cannot remove empty entry")
 	}
 
-	if fi.contains(item) {
-		// Exists in item set, remove it and sync file
-		fi.items.Delete(strings.ToLower(item))
-		// Sync with the file
-		return fi.rewriteFile()
+	lower := strings.ToLower(item)
+	if _, ok := fi.items[lower]; ok {
+		// Exists in item set, remove it and sync backend
+		delete(fi.items, lower)
+		// Sync with the backend
+		if err := fi.rewrite(ctx); err != nil {
+			return err
+		}
+		fi.publish(Event{Op: OpRemove, Item: item})
+		return nil
 	}
 
 	// Unknown item (not exists in items set)
@@ -80,9 +113,17 @@ func (fi *FileItem) Remove(item string) error {
 // Contains returns true, if FileItem has this item in memory. It compares two
 // strings by strings.EqualFold method.
 func (fi *FileItem) Contains(item string) bool {
-	fi.itemsMu.Lock()
+	ok, _ := fi.ContainsContext(context.Background(), item)
+	return ok
+}
+
+// ContainsContext is Contains, honoring ctx for lock acquisition.
+func (fi *FileItem) ContainsContext(ctx context.Context, item string) (bool, error) {
+	if err := fi.itemsMu.Lock(ctx); err != nil {
+		return false, err
+	}
 	defer fi.itemsMu.Unlock()
-	return fi.contains(item)
+	return fi.contains(item), nil
 }
 
 func (fi *FileItem) contains(item string) bool {
@@ -90,25 +131,29 @@ func (fi *FileItem) contains(item string) bool {
 	if item == "" {
 		return false
 	}
-	return fi.items.Contains(strings.ToLower(item))
+	_, ok := fi.items[strings.ToLower(item)]
+	return ok
 }
 
-// Items returns the iterator of items.
+// Items returns the iterator of items, in their original case.
 func (fi *FileItem) Items() iter.Seq[string] {
-	fi.itemsMu.Lock()
+	// New's ctxMutex never expires context.Background(), so this can't fail.
+	_ = fi.itemsMu.Lock(context.Background())
 	defer fi.itemsMu.Unlock()
-	return fi.items.Values()
+	items := fi.items
+	return func(yield func(string) bool) {
+		for _, original := range items {
+			if !yield(original) {
+				return
+			}
+		}
+	}
 }
 
-// load loads the entries from path.
-func (fi *FileItem) load() error {
-	data, err := os.ReadFile(fi.path)
+// loadContext loads the entries from the backend.
+func (fi *FileItem) loadContext(ctx context.Context) error {
+	data, err := fi.backend.Read(ctx)
 	if err != nil {
-		if os.IsNotExist(err) {
-			// Create empty file, even if it is not exists
-			return os.WriteFile(fi.path, []byte{}, 0644)
-		}
-		// Unexpected error.
 		return err
 	}
 
@@ -118,42 +163,19 @@ func (fi *FileItem) load() error {
 	for _, line := range lines {
 		if line = strings.TrimSpace(line); line != "" {
 			// Add, if it is not blank string
-			fi.items.Add(strings.ToLower(line))
+			fi.items[strings.ToLower(line)] = line
 		}
 	}
 
 	return nil
 }
 
-// appendToFile appends the item to end of the file.
-func (fi *FileItem) appendToFile(item string) error {
-	f, err := os.OpenFile(fi.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		// Don't forget to free file
-		_ = f.Close()
-	}()
-
-	if stat, _ := f.Stat(); stat != nil && stat.Size() > 0 {
-		// Adding new line, if file is not empty
-		if _, err := f.WriteString("\n"); err != nil {
-			return err
-		}
-	}
-
-	// Finally, writing the item string to file
-	_, err = f.WriteString(item)
-	return err
-}
-
-// rewriteFile fully rewrites the file with data in memory.
-func (fi *FileItem) rewriteFile() error {
-	var lines []string
-	for item := range fi.items.Values() {
-		lines = append(lines, item)
+// rewrite fully rewrites the backend with data in memory.
+func (fi *FileItem) rewrite(ctx context.Context) error {
+	lines := make([]string, 0, len(fi.items))
+	for _, original := range fi.items {
+		lines = append(lines, original)
 	}
 	content := strings.Join(lines, "\n")
-	return os.WriteFile(fi.path, []byte(content), 0644)
+	return fi.backend.Rewrite(ctx, []byte(content))
 }
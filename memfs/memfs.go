@@ -0,0 +1,50 @@
+// Package memfs provides an in-memory fileitem.Backend, useful for tests and
+// ephemeral use where nothing needs to survive the process.
+package memfs
+
+import (
+	"bytes"
+	"context"
+	"sync"
+)
+
+// Backend holds its contents as a byte slice in memory.
+type Backend struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+// New creates a new, empty Backend.
+func New() *Backend {
+	return &Backend{}
+}
+
+// Read returns a copy of the backend's current contents. Returns an empty,
+// non-nil slice rather than nil when nothing has been stored yet.
+func (b *Backend) Read(ctx context.Context) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.data) == 0 {
+		return []byte{}, nil
+	}
+	return bytes.Clone(b.data), nil
+}
+
+// Append appends entry to the end of the backend's contents.
+func (b *Backend) Append(ctx context.Context, entry string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.data) > 0 {
+		b.data = append(b.data, '\n')
+	}
+	b.data = append(b.data, entry...)
+	return nil
+}
+
+// Rewrite fully replaces the backend's contents with data.
+func (b *Backend) Rewrite(ctx context.Context, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = bytes.Clone(data)
+	return nil
+}
@@ -0,0 +1,62 @@
+package memfs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/k4ties/fileitem/memfs"
+)
+
+func TestBackendReadEmpty(t *testing.T) {
+	b := memfs.New()
+	data, err := b.Read(context.Background())
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if data == nil {
+		t.Fatal("expected an empty, non-nil slice per the Backend.Read contract")
+	}
+	if len(data) != 0 {
+		t.Fatalf("expected empty contents, got %q", data)
+	}
+}
+
+func TestBackendAppendAndRead(t *testing.T) {
+	b := memfs.New()
+	ctx := context.Background()
+
+	if err := b.Append(ctx, "first"); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := b.Append(ctx, "second"); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	data, err := b.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got, want := string(data), "first\nsecond"; got != want {
+		t.Fatalf("Read = %q, want %q", got, want)
+	}
+}
+
+func TestBackendRewriteReplacesContents(t *testing.T) {
+	b := memfs.New()
+	ctx := context.Background()
+
+	if err := b.Append(ctx, "stale"); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := b.Rewrite(ctx, []byte("fresh")); err != nil {
+		t.Fatalf("Rewrite: %v", err)
+	}
+
+	data, err := b.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got, want := string(data), "fresh"; got != want {
+		t.Fatalf("Read = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package osfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// ErrChecksumMismatch is returned by Read (when Opts.Checksum is set) and by
+// Verify (whatever Opts.Checksum is) when the backing file's contents don't
+// match its ".sha256" sidecar.
+var ErrChecksumMismatch = errors.New("osfs: checksum mismatch")
+
+func (b *Backend) checksumPath() string {
+	return b.Path + ".sha256"
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeChecksum overwrites the sidecar file alongside Path with data's
+// digest, via the same durable temp-file-then-rename sequence Rewrite uses
+// for Path itself, rather than a bare WriteFile. Without this, Durable and
+// Checksum fought each other: the sidecar could be left truncated or
+// unsynced by a crash independently of whether Path's own write landed.
+func (b *Backend) writeChecksum(data []byte) error {
+	tmp := b.checksumPath() + b.tempSuffix()
+	if err := writeDurable(tmp, []byte(digest(data)), b.Opts.Durable); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, b.checksumPath()); err != nil {
+		return err
+	}
+	if b.Opts.Durable && b.Opts.DirSync {
+		return syncDir(filepath.Dir(b.checksumPath()))
+	}
+	return nil
+}
+
+// updateChecksumFromFile re-reads the backing file and updates its sidecar
+// to match, for callers (Append) that only have the delta in hand.
+func (b *Backend) updateChecksumFromFile() error {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return err
+	}
+	return b.writeChecksum(data)
+}
+
+// verify compares data's digest against the sidecar file, if one exists. A
+// missing sidecar is not an error: there's nothing to have drifted from yet.
+func (b *Backend) verify(data []byte) error {
+	want, err := os.ReadFile(b.checksumPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if string(want) != digest(data) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+// Verify re-reads the backing file and its sidecar and reports whether they
+// still agree, regardless of whether Opts.Checksum is set.
+func (b *Backend) Verify(ctx context.Context) error {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return b.verify(data)
+}
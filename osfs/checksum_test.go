@@ -0,0 +1,56 @@
+package osfs_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k4ties/fileitem/osfs"
+)
+
+func TestReadDetectsTamperedContents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.txt")
+	b := osfs.New(path, osfs.Options{Checksum: true})
+	ctx := context.Background()
+
+	if err := b.Rewrite(ctx, []byte("alice\nbob")); err != nil {
+		t.Fatalf("Rewrite: %v", err)
+	}
+
+	if _, err := b.Read(ctx); err != nil {
+		t.Fatalf("Read of untampered contents: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("alice\nmallory"), 0644); err != nil {
+		t.Fatalf("tamper write: %v", err)
+	}
+
+	if _, err := b.Read(ctx); !errors.Is(err, osfs.ErrChecksumMismatch) {
+		t.Fatalf("Read after tamper = %v, want ErrChecksumMismatch", err)
+	}
+	if err := b.Verify(ctx); !errors.Is(err, osfs.ErrChecksumMismatch) {
+		t.Fatalf("Verify after tamper = %v, want ErrChecksumMismatch", err)
+	}
+}
+
+func TestVerifyPassesWithoutTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.txt")
+	b := osfs.New(path, osfs.Options{Checksum: true, Durable: true})
+	ctx := context.Background()
+
+	if err := b.Rewrite(ctx, []byte("alice\nbob")); err != nil {
+		t.Fatalf("Rewrite: %v", err)
+	}
+	if err := b.Verify(ctx); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	if err := b.Append(ctx, "carol"); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := b.Verify(ctx); err != nil {
+		t.Fatalf("Verify after Append: %v", err)
+	}
+}
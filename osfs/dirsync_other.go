@@ -0,0 +1,8 @@
+//go:build !unix
+
+package osfs
+
+// syncDir is a no-op outside Unix, where directory fsync isn't a thing.
+func syncDir(dir string) error {
+	return nil
+}
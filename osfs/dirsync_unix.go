@@ -0,0 +1,17 @@
+//go:build unix
+
+package osfs
+
+import "os"
+
+// syncDir fsyncs dir so that a prior rename into it is durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = d.Close()
+	}()
+	return d.Sync()
+}
@@ -0,0 +1,74 @@
+package osfs_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k4ties/fileitem/osfs"
+)
+
+func TestRewriteUsesTempFileThenRename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "list.txt")
+	b := osfs.New(path)
+	ctx := context.Background()
+
+	if err := b.Rewrite(ctx, []byte("alice\nbob")); err != nil {
+		t.Fatalf("Rewrite: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != filepath.Base(path) {
+		t.Fatalf("expected only %q to remain in %s, found %v", filepath.Base(path), dir, entries)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if got, want := string(data), "alice\nbob"; got != want {
+		t.Fatalf("contents = %q, want %q", got, want)
+	}
+}
+
+func TestRewriteDurableAndDirSyncDontError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "list.txt")
+	b := osfs.New(path, osfs.Options{Durable: true, DirSync: true})
+	ctx := context.Background()
+
+	if err := b.Rewrite(ctx, []byte("alice")); err != nil {
+		t.Fatalf("Rewrite: %v", err)
+	}
+	if err := b.Append(ctx, "bob"); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if got, want := string(data), "alice\nbob"; got != want {
+		t.Fatalf("contents = %q, want %q", got, want)
+	}
+}
+
+func TestRewriteCustomTempSuffixIsCleanedUp(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "list.txt")
+	b := osfs.New(path, osfs.Options{TempSuffix: ".staging"})
+	ctx := context.Background()
+
+	if err := b.Rewrite(ctx, []byte("alice")); err != nil {
+		t.Fatalf("Rewrite: %v", err)
+	}
+
+	if _, err := os.Stat(path + ".staging"); !os.IsNotExist(err) {
+		t.Fatalf("expected temp file %q to be renamed away, stat err = %v", path+".staging", err)
+	}
+}
@@ -0,0 +1,176 @@
+// Package osfs provides a fileitem.Backend backed by a plain text file on
+// the local filesystem. It preserves the semantics FileItem used before its
+// storage was made pluggable: one entry per line, appended to or rewritten
+// in place.
+package osfs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// defaultTempSuffix is used when Options.TempSuffix is left empty.
+const defaultTempSuffix = ".tmp"
+
+// Options configures how a Backend persists to disk.
+type Options struct {
+	// Durable, when true, fsyncs after every write so that Append and
+	// Rewrite are crash-safe: Append fsyncs the file itself, and Rewrite
+	// fsyncs its temporary file before the rename that makes it visible.
+	Durable bool
+
+	// TempSuffix is appended to a Backend's Path to name the temporary
+	// file Rewrite stages its contents in before renaming it into place.
+	// Defaults to ".tmp" if empty.
+	TempSuffix string
+
+	// DirSync additionally fsyncs the parent directory after Rewrite's
+	// rename, on Unix, so the rename itself survives a crash. Only takes
+	// effect when Durable is true.
+	DirSync bool
+
+	// Checksum, when true, maintains a "<Path>.sha256" sidecar alongside
+	// Path and verifies the backing file against it on every Read, so
+	// corruption or tampering is surfaced as ErrChecksumMismatch instead
+	// of silently changing the loaded item set.
+	Checksum bool
+}
+
+// Backend stores entries in a text file at Path.
+type Backend struct {
+	Path string
+	Opts Options
+}
+
+// New creates a Backend rooted at path, configured by opts. Passing no opts
+// preserves FileItem's original, non-durable behaviour.
+func New(path string, opts ...Options) *Backend {
+	b := &Backend{Path: path}
+	if len(opts) > 0 {
+		b.Opts = opts[0]
+	}
+	return b
+}
+
+func (b *Backend) tempSuffix() string {
+	if b.Opts.TempSuffix != "" {
+		return b.Opts.TempSuffix
+	}
+	return defaultTempSuffix
+}
+
+// writeDurable writes data to path, truncating it if it already exists, and
+// fsyncs before closing when durable is true.
+func writeDurable(path string, data []byte, durable bool) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if durable {
+		if err := f.Sync(); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	return f.Close()
+}
+
+// Read returns the contents of the backing file, creating it if it does not
+// yet exist. When Opts.Checksum is set, the contents are verified against
+// the "<Path>.sha256" sidecar first, returning ErrChecksumMismatch on
+// disagreement.
+func (b *Backend) Read(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Create empty file, even if it is not exists
+			if err := os.WriteFile(b.Path, []byte{}, 0644); err != nil {
+				return nil, err
+			}
+			return []byte{}, nil
+		}
+		// Unexpected error.
+		return nil, err
+	}
+
+	if b.Opts.Checksum {
+		if err := b.verify(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// Append appends entry to the end of the backing file.
+func (b *Backend) Append(ctx context.Context, entry string) error {
+	f, err := os.OpenFile(b.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if stat, _ := f.Stat(); stat != nil && stat.Size() > 0 {
+		// Adding new line, if file is not empty
+		if _, err := f.WriteString("\n"); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	if _, err := f.WriteString(entry); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if b.Opts.Durable {
+		// Flush to disk before the entry is considered committed.
+		if err := f.Sync(); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if b.Opts.Checksum {
+		return b.updateChecksumFromFile()
+	}
+	return nil
+}
+
+// Rewrite fully replaces the backing file's contents with data. It writes
+// to a temporary file in the same directory first and renames it into
+// place, so a crash mid-write cannot leave Path truncated or half-written.
+func (b *Backend) Rewrite(ctx context.Context, data []byte) error {
+	dir := filepath.Dir(b.Path)
+	tmp := b.Path + b.tempSuffix()
+
+	if err := writeDurable(tmp, data, b.Opts.Durable); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, b.Path); err != nil {
+		return err
+	}
+
+	if b.Opts.Durable && b.Opts.DirSync {
+		if err := syncDir(dir); err != nil {
+			return err
+		}
+	}
+
+	if b.Opts.Checksum {
+		return b.writeChecksum(data)
+	}
+	return nil
+}
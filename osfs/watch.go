@@ -0,0 +1,64 @@
+package osfs
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch implements fileitem.Watchable: it signals whenever Path is written,
+// created or renamed on disk; removal of Path and permission changes are not
+// signalled. It watches Path's parent directory rather than Path itself, since
+// Rewrite replaces Path by renaming a temporary file into place, which on
+// some platforms drops a watch held on the old file directly.
+func (b *Backend) Watch(ctx context.Context) (<-chan struct{}, func(), error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := watcher.Add(filepath.Dir(b.Path)); err != nil {
+		_ = watcher.Close()
+		return nil, nil, err
+	}
+
+	changes := make(chan struct{}, 1)
+	done := make(chan struct{})
+
+	go func() {
+		defer watcher.Close()
+		defer close(changes)
+		for {
+			select {
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(ev.Name) != filepath.Clean(b.Path) {
+					continue
+				}
+				if ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				select {
+				case changes <- struct{}{}:
+				default:
+					// A reload is already pending; no need to queue another.
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	stop := func() {
+		close(done)
+	}
+	return changes, stop, nil
+}
@@ -0,0 +1,168 @@
+// Package s3 provides a fileitem.Backend that persists its contents as a
+// single object in an S3-compatible bucket, so a FileItem can be shared by
+// multiple processes/instances without operating a database.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// DefaultMaxRetries is used when Backend.MaxRetries is left at zero.
+const DefaultMaxRetries = 3
+
+// Config holds what's needed to reach an S3-compatible endpoint and locate
+// the object a Backend persists to.
+type Config struct {
+	Endpoint  string
+	Bucket    string
+	Key       string
+	AccessKey string
+	SecretKey string
+	// Secure selects TLS for the connection to Endpoint.
+	Secure bool
+}
+
+// Backend is a fileitem.Backend backed by a single object at Bucket/Key.
+// Writers race via Stat'd ETags: a write is only accepted if the object's
+// ETag still matches what was last read, and on a lost race the backend
+// reloads and retries up to MaxRetries times.
+type Backend struct {
+	client *minio.Client
+	bucket string
+	key    string
+
+	// MaxRetries bounds how many times Append/Rewrite retry after losing
+	// a race to another writer. Defaults to DefaultMaxRetries if zero.
+	MaxRetries int
+}
+
+// New connects to the endpoint described by cfg and returns a Backend for
+// cfg.Bucket/cfg.Key.
+func New(cfg Config) (*Backend, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.Secure,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{client: client, bucket: cfg.Bucket, key: cfg.Key}, nil
+}
+
+func (b *Backend) maxRetries() int {
+	if b.MaxRetries > 0 {
+		return b.MaxRetries
+	}
+	return DefaultMaxRetries
+}
+
+// Read returns the object's current contents. A missing object is treated
+// as empty, not an error.
+func (b *Backend) Read(ctx context.Context) ([]byte, error) {
+	data, _, err := b.read(ctx)
+	return data, err
+}
+
+// Append adds entry to the end of the object's contents.
+func (b *Backend) Append(ctx context.Context, entry string) error {
+	return b.cas(ctx, func(data []byte) []byte {
+		if len(data) > 0 {
+			data = append(data, '\n')
+		}
+		return append(data, entry...)
+	})
+}
+
+// Rewrite replaces the object's entire contents with data.
+func (b *Backend) Rewrite(ctx context.Context, data []byte) error {
+	return b.cas(ctx, func([]byte) []byte {
+		return data
+	})
+}
+
+// cas reads the current contents and ETag, derives the next contents via
+// next, and puts them back, retrying on a lost race against another writer.
+func (b *Backend) cas(ctx context.Context, next func(current []byte) []byte) error {
+	var lastErr error
+	for attempt := 0; attempt <= b.maxRetries(); attempt++ {
+		data, etag, err := b.read(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = b.put(ctx, next(data), etag)
+		if err == nil {
+			return nil
+		}
+		if !isPreconditionFailed(err) {
+			return err
+		}
+		// Lost the race to another writer; reload and retry.
+		lastErr = err
+	}
+	return fmt.Errorf("fileitem/s3: exceeded %d retries: %w", b.maxRetries(), lastErr)
+}
+
+// read returns the object's contents and ETag. A missing object is reported
+// as empty contents, an empty ETag, and no error.
+func (b *Backend) read(ctx context.Context) ([]byte, string, error) {
+	obj, err := b.client.GetObject(ctx, b.bucket, b.key, minio.GetObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return []byte{}, "", nil
+		}
+		return nil, "", err
+	}
+	defer func() {
+		// Don't forget to free the object
+		_ = obj.Close()
+	}()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return []byte{}, "", nil
+		}
+		return nil, "", err
+	}
+
+	info, err := obj.Stat()
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return []byte{}, "", nil
+		}
+		return nil, "", err
+	}
+
+	return data, info.ETag, nil
+}
+
+// put uploads data as the object's contents, conditioned on the object's
+// ETag (or, when etag is empty because the object isn't expected to exist
+// yet, on no object existing at all) so the PUT itself is rejected
+// atomically by the server on a stale read, instead of racing a
+// client-side ETag comparison against a concurrent writer.
+func (b *Backend) put(ctx context.Context, data []byte, etag string) error {
+	opts := minio.PutObjectOptions{ContentType: "text/plain"}
+	if etag == "" {
+		opts.SetMatchETagExcept("*")
+	} else {
+		opts.SetMatchETag(etag)
+	}
+
+	_, err := b.client.PutObject(ctx, b.bucket, b.key, bytes.NewReader(data), int64(len(data)), opts)
+	return err
+}
+
+// isPreconditionFailed reports whether err is the server rejecting a put's
+// If-Match/If-None-Match condition, i.e. a lost race against another writer.
+func isPreconditionFailed(err error) bool {
+	code := minio.ToErrorResponse(err).Code
+	return code == "PreconditionFailed"
+}
@@ -0,0 +1,75 @@
+package fileitem
+
+// subscriberBuffer bounds how many Events a slow subscriber can fall behind
+// on before older ones are dropped rather than blocking the rest of
+// FileItem.
+const subscriberBuffer = 16
+
+// Op identifies what kind of change an Event describes.
+type Op int
+
+const (
+	// OpAdd indicates Item was added to the FileItem.
+	OpAdd Op = iota
+	// OpRemove indicates Item was removed from the FileItem.
+	OpRemove
+	// OpReload indicates the FileItem reloaded its entire set from the
+	// backend, e.g. after Watch detected an external edit. Item is
+	// empty; the entries that actually changed are published as
+	// OpAdd/OpRemove events immediately before it.
+	OpReload
+)
+
+// Event describes a single change to a FileItem's item set.
+type Event struct {
+	Op   Op
+	Item string
+}
+
+// Subscribe registers for Events emitted by fi, returning a channel to
+// receive them and a function to unsubscribe. The channel is buffered; a
+// subscriber that falls too far behind has its oldest pending events
+// dropped rather than blocking fi's other callers.
+func (fi *FileItem) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	fi.subsMu.Lock()
+	if fi.subs == nil {
+		fi.subs = make(map[chan Event]struct{})
+	}
+	fi.subs[ch] = struct{}{}
+	fi.subsMu.Unlock()
+
+	unsubscribe := func() {
+		fi.subsMu.Lock()
+		delete(fi.subs, ch)
+		fi.subsMu.Unlock()
+		close(ch)
+	}
+	return ch, unsubscribe
+}
+
+// publish delivers ev to every current subscriber. A subscriber whose
+// channel is full has its oldest pending event dropped to make room,
+// rather than blocking the caller or losing ev itself.
+func (fi *FileItem) publish(ev Event) {
+	fi.subsMu.Lock()
+	defer fi.subsMu.Unlock()
+	for ch := range fi.subs {
+		select {
+		case ch <- ev:
+		default:
+			select {
+			case <-ch:
+				// Dropped the oldest buffered event to make room.
+			default:
+			}
+			select {
+			case ch <- ev:
+			default:
+				// A concurrent receiver raced us for the slot we just
+				// freed; give up rather than block the publisher.
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package fileitem_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k4ties/fileitem"
+	"github.com/k4ties/fileitem/memfs"
+)
+
+func TestSubscribeReceivesAddAndRemove(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(memfs.New())
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+
+	events, unsubscribe := fi.Subscribe()
+	defer unsubscribe()
+
+	if err := fi.Add("alice"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := fi.Remove("alice"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+
+	want := []fileitem.Event{
+		{Op: fileitem.OpAdd, Item: "alice"},
+		{Op: fileitem.OpRemove, Item: "alice"},
+	}
+	for i, w := range want {
+		select {
+		case got := <-events:
+			if got != w {
+				t.Fatalf("event %d = %+v, want %+v", i, got, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+}
+
+func TestSubscribeReceivesBatchDiff(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(memfs.New())
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+	if err := fi.Add("kept"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	events, unsubscribe := fi.Subscribe()
+	defer unsubscribe()
+
+	err = fi.Batch(func(tx *fileitem.Tx) error {
+		if err := tx.Add("new-one"); err != nil {
+			return err
+		}
+		return tx.Remove("kept")
+	})
+	if err != nil {
+		t.Fatalf("Batch: %v", err)
+	}
+
+	seen := make(map[fileitem.Event]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case ev := <-events:
+			seen[ev] = true
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for batch event %d", i)
+		}
+	}
+
+	if !seen[fileitem.Event{Op: fileitem.OpAdd, Item: "new-one"}] {
+		t.Error("missing OpAdd event for new-one")
+	}
+	if !seen[fileitem.Event{Op: fileitem.OpRemove, Item: "kept"}] {
+		t.Error("missing OpRemove event for kept")
+	}
+}
+
+func TestUnsubscribeStopsDelivery(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(memfs.New())
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+
+	events, unsubscribe := fi.Subscribe()
+	unsubscribe()
+
+	if err := fi.Add("alice"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	select {
+	case ev, ok := <-events:
+		if ok {
+			t.Fatalf("expected channel closed after unsubscribe, got %+v", ev)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("channel should have been closed by unsubscribe")
+	}
+}
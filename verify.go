@@ -0,0 +1,29 @@
+package fileitem
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrVerifyUnsupported is returned by Verify when the FileItem's backend
+// does not support integrity verification.
+var ErrVerifyUnsupported = errors.New("fileitem: backend does not support verification")
+
+// verifier is implemented by backends that can re-check the integrity of
+// their stored contents on demand, such as osfs (which checks its ".sha256"
+// sidecar whether or not Options.Checksum is set).
+type verifier interface {
+	Verify(ctx context.Context) error
+}
+
+// Verify asks the backend to recheck the integrity of its stored contents,
+// if it supports doing so, returning ErrVerifyUnsupported otherwise. This is
+// for on-demand rechecks; Read-time verification (where supported) already
+// happens on every load.
+func (fi *FileItem) Verify() error {
+	v, ok := fi.backend.(verifier)
+	if !ok {
+		return ErrVerifyUnsupported
+	}
+	return v.Verify(context.Background())
+}
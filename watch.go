@@ -0,0 +1,80 @@
+package fileitem
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrWatchUnsupported is returned by Watch when the FileItem's backend
+// does not implement Watchable.
+var ErrWatchUnsupported = errors.New("fileitem: backend does not support watching")
+
+// Watchable is implemented by backends that can notify a FileItem of
+// changes made to their contents outside of FileItem itself, e.g. osfs
+// watching its file on disk with fsnotify.
+type Watchable interface {
+	// Watch starts watching for external changes and returns a channel
+	// that receives a signal whenever one is detected, and a function to
+	// stop watching. The channel is closed once stopped.
+	Watch(ctx context.Context) (changes <-chan struct{}, stop func(), err error)
+}
+
+// Watch opts fi into reloading from its backend whenever the backend
+// reports an external change (see Watchable). Each reload publishes an
+// OpAdd/OpRemove Event for every entry that was actually added or removed,
+// followed by a single OpReload Event marking the reload complete. It
+// returns a function to stop watching, or ErrWatchUnsupported if the
+// backend isn't Watchable.
+func (fi *FileItem) Watch(ctx context.Context) (func(), error) {
+	w, ok := fi.backend.(Watchable)
+	if !ok {
+		return nil, ErrWatchUnsupported
+	}
+
+	changes, stop, err := w.Watch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for range changes {
+			fi.reload(ctx)
+		}
+	}()
+
+	return stop, nil
+}
+
+// reload re-reads the backend, diffs the result against the in-memory set,
+// and publishes Events for what changed.
+func (fi *FileItem) reload(ctx context.Context) {
+	if err := fi.itemsMu.Lock(ctx); err != nil {
+		return
+	}
+
+	before := fi.items
+	fi.items = make(map[string]string)
+	err := fi.loadContext(ctx)
+	after := fi.items
+	if err != nil {
+		// Reload failed; keep serving the previous, known-good set.
+		fi.items = before
+	}
+	fi.itemsMu.Unlock()
+
+	if err != nil {
+		return
+	}
+
+	for lower, item := range after {
+		if _, ok := before[lower]; !ok {
+			fi.publish(Event{Op: OpAdd, Item: item})
+		}
+	}
+	for lower, item := range before {
+		if _, ok := after[lower]; !ok {
+			fi.publish(Event{Op: OpRemove, Item: item})
+		}
+	}
+	fi.publish(Event{Op: OpReload})
+}
@@ -0,0 +1,97 @@
+package fileitem_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k4ties/fileitem"
+	"github.com/k4ties/fileitem/memfs"
+)
+
+// watchableMemBackend adds a manually-triggerable fileitem.Watchable to
+// memfs.Backend, so Watch/reload can be exercised without real filesystem
+// events.
+type watchableMemBackend struct {
+	*memfs.Backend
+	changes chan struct{}
+}
+
+func newWatchableMemBackend() *watchableMemBackend {
+	return &watchableMemBackend{Backend: memfs.New(), changes: make(chan struct{}, 1)}
+}
+
+func (b *watchableMemBackend) Watch(context.Context) (<-chan struct{}, func(), error) {
+	return b.changes, func() {}, nil
+}
+
+func (b *watchableMemBackend) trigger() {
+	select {
+	case b.changes <- struct{}{}:
+	default:
+	}
+}
+
+func TestWatchReloadsAndDiffsOnExternalChange(t *testing.T) {
+	backend := newWatchableMemBackend()
+	fi, err := fileitem.NewWithBackend(backend)
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+	if err := fi.Add("alice"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	events, unsubscribe := fi.Subscribe()
+	defer unsubscribe()
+
+	stop, err := fi.Watch(context.Background())
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer stop()
+
+	// Simulate another process editing the backing contents directly.
+	if err := backend.Rewrite(context.Background(), []byte("alice\nbob")); err != nil {
+		t.Fatalf("Rewrite: %v", err)
+	}
+	backend.trigger()
+
+	var gotAdd, gotReload bool
+	for i := 0; i < 2; i++ {
+		select {
+		case ev := <-events:
+			switch ev.Op {
+			case fileitem.OpAdd:
+				if ev.Item == "bob" {
+					gotAdd = true
+				}
+			case fileitem.OpReload:
+				gotReload = true
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for reload events")
+		}
+	}
+
+	if !gotAdd {
+		t.Error("expected an OpAdd event for bob")
+	}
+	if !gotReload {
+		t.Error("expected a terminal OpReload event")
+	}
+	if !fi.Contains("bob") {
+		t.Error("FileItem should have reloaded bob from the backend")
+	}
+}
+
+func TestWatchUnsupportedBackend(t *testing.T) {
+	fi, err := fileitem.NewWithBackend(memfs.New())
+	if err != nil {
+		t.Fatalf("NewWithBackend: %v", err)
+	}
+
+	if _, err := fi.Watch(context.Background()); err != fileitem.ErrWatchUnsupported {
+		t.Fatalf("Watch error = %v, want ErrWatchUnsupported", err)
+	}
+}